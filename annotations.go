@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FieldAnnotation holds OpenAPI details of a field that can't be expressed by
+// its schema.Field definition. Zero values are ignored.
+type FieldAnnotation struct {
+	Description string
+	Format      string
+	Example     interface{}
+	Deprecated  bool
+	// Extensions are x- extensions added to the field schema.
+	Extensions map[string]interface{}
+}
+
+// WithFieldAnnotation annotates the field fieldName of the resource at path
+// (i.e. "users.posts").
+func WithFieldAnnotation(path, fieldName string, a FieldAnnotation) Option {
+	return func(g *generator) {
+		if g.annotations == nil {
+			g.annotations = map[string]map[string]FieldAnnotation{}
+		}
+		if g.annotations[path] == nil {
+			g.annotations[path] = map[string]FieldAnnotation{}
+		}
+		g.annotations[path][fieldName] = a
+	}
+}
+
+// applyAnnotations merges the annotations of the fields of the resource at
+// path into the properties of its schema s.
+func (g *generator) applyAnnotations(path string, s *openapi3.Schema) {
+	for fieldName, a := range g.annotations[path] {
+		prop, found := s.Properties[fieldName]
+		if !found || prop.Value == nil {
+			continue
+		}
+		a.apply(prop.Value)
+	}
+}
+
+func (a FieldAnnotation) apply(s *openapi3.Schema) {
+	if a.Description != "" {
+		s.Description = a.Description
+	}
+	if a.Format != "" {
+		s.Format = a.Format
+	}
+	if a.Example != nil {
+		s.Example = a.Example
+	}
+	if a.Deprecated || len(a.Extensions) > 0 {
+		if s.Extensions == nil {
+			s.Extensions = map[string]interface{}{}
+		}
+	}
+	if a.Deprecated {
+		// Workaround: openapi3.Schema has no Deprecated field in the
+		// kin-openapi version in use. The encoder writes any Extensions key
+		// as is, so this renders the standard deprecated keyword, but it is
+		// not an x- extension and is not decoded back into the schema.
+		s.Extensions["deprecated"] = true
+	}
+	for name, value := range a.Extensions {
+		s.Extensions[name] = value
+	}
+}
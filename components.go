@@ -18,18 +18,6 @@ var staticComponents = openapi3.Components{
 				},
 			},
 		},
-		"fields": {
-			Value: &openapi3.Parameter{
-				Description: "[Select](http://rest-layer.io/#field-selection) which fields to show, including [embedding](http://rest-layer.io/#embedding) of related resources.",
-				Name:        "fields",
-				In:          "query",
-				Schema: &openapi3.SchemaRef{
-					Value: &openapi3.Schema{
-						Type: "string",
-					},
-				},
-			},
-		},
 		"limit": {
 			Value: &openapi3.Parameter{
 				Description: "Limit maximum entries per [page](http://rest-layer.io/#paginatio).",
@@ -72,17 +60,15 @@ var staticComponents = openapi3.Components{
 		},
 		"total": {
 			Value: &openapi3.Parameter{
-				Description: "Force total number of entries to be included in the response header. This could have performance implications.",
+				Description: "Set to 1 to force the total number of entries to be included in the response header. This could have performance implications.",
 				Name:        "total",
 				In:          "query",
 				Schema: &openapi3.SchemaRef{
 					Value: &openapi3.Schema{
-						/*
-							Type: "integer",
-							Default:  openapi3.Float64Ptr(0),
-						*/
-						Type:    "boolean",
-						Default: false,
+						// rest-layer only honours total=1.
+						Type:    "integer",
+						Enum:    []interface{}{0, 1},
+						Default: 0,
 					},
 				},
 			},
@@ -91,11 +77,11 @@ var staticComponents = openapi3.Components{
 	Headers: map[string]*openapi3.HeaderRef{
 		"Date": {
 			Value: &openapi3.Header{
-				Description: "The time this request was served.",
+				Description: "The time this request was served, as an [HTTP-date](https://tools.ietf.org/html/rfc7231#section-7.1.1.1).",
 				Schema: &openapi3.SchemaRef{
 					Value: &openapi3.Schema{
-						Type:   "string",
-						//Format: "date-time",
+						Type:    "string",
+						Example: "Mon, 02 Jan 2006 15:04:05 GMT",
 					},
 				},
 			},
@@ -112,11 +98,21 @@ var staticComponents = openapi3.Components{
 		},
 		"Last-Modified": {
 			Value: &openapi3.Header{
-				Description: "When this resource was last modified.",
+				Description: "When this resource was last modified, as an [HTTP-date](https://tools.ietf.org/html/rfc7231#section-7.1.1.1).",
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type:    "string",
+						Example: "Mon, 02 Jan 2006 15:04:05 GMT",
+					},
+				},
+			},
+		},
+		"Content-Location": {
+			Value: &openapi3.Header{
+				Description: "The path of the created item.",
 				Schema: &openapi3.SchemaRef{
 					Value: &openapi3.Schema{
-						Type:   "string",
-						//Format: "date-time",
+						Type: "string",
 					},
 				},
 			},
@@ -214,3 +210,28 @@ var staticComponents = openapi3.Components{
 		},
 	},
 }
+
+// newComponents returns a copy of staticComponents that can be extended with
+// the components of an index without altering staticComponents.
+func newComponents() openapi3.Components {
+	c := staticComponents
+
+	c.Parameters = map[string]*openapi3.ParameterRef{}
+	for name, param := range staticComponents.Parameters {
+		c.Parameters[name] = param
+	}
+	c.Headers = map[string]*openapi3.HeaderRef{}
+	for name, header := range staticComponents.Headers {
+		c.Headers[name] = header
+	}
+	c.Schemas = map[string]*openapi3.SchemaRef{}
+	for name, schema := range staticComponents.Schemas {
+		c.Schemas[name] = schema
+	}
+	c.Responses = map[string]*openapi3.ResponseRef{}
+	for name, response := range staticComponents.Responses {
+		c.Responses[name] = response
+	}
+
+	return c
+}
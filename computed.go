@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+// describeComputed flags the fields set by the server as read only, and
+// documents when their value is computed in the x-rest-layer-computed
+// extension.
+func describeComputed(f schema.Field, s *openapi3.Schema) {
+	on := []string{}
+	if f.OnInit != nil {
+		on = append(on, "create")
+	}
+	if f.OnUpdate != nil {
+		on = append(on, "update")
+	}
+	if f.Handler != nil {
+		on = append(on, "read")
+	}
+
+	// A field set both on creation and on update is always overwritten by
+	// the server.
+	if f.ReadOnly || (f.OnInit != nil && f.OnUpdate != nil) {
+		s.ReadOnly = true
+	}
+
+	if len(on) == 0 {
+		return
+	}
+	if s.Extensions == nil {
+		s.Extensions = map[string]interface{}{}
+	}
+	s.Extensions["x-rest-layer-computed"] = map[string]interface{}{
+		"on": on,
+	}
+}
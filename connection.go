@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/schema"
+)
+
+func generateSchemaFromFieldConnection(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.Connection)
+	ret := &openapi3.Schema{
+		Type:     "array",
+		ReadOnly: true,
+		Description: fmt.Sprintf(
+			"Items of %s whose %s field references this item. Only present when requested with the fields parameter.",
+			strings.TrimPrefix(v.Path, "."), v.Field,
+		),
+		Items: &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Type: "object",
+			},
+		},
+	}
+	ret.Extensions = map[string]interface{}{
+		"x-rest-layer-connection": map[string]string{
+			"path":  v.Path,
+			"field": v.Field,
+		},
+	}
+
+	return ret
+}
+
+// resolveConnections points the items of the connection fields of s, the
+// schema of rsc, to the schema of the connected resource.
+func (g *generator) resolveConnections(rsc *resource.Resource, s schema.Schema, ret *openapi3.Schema) {
+	for fieldName, field := range s.Fields {
+		v, ok := field.Validator.(*schema.Connection)
+		if !ok {
+			continue
+		}
+		prop := ret.Properties[fieldName]
+		if prop == nil || prop.Value == nil || prop.Value.Items == nil {
+			continue
+		}
+
+		if name, found := g.schemaNames[connectionPath(rsc, v)]; found {
+			prop.Value.Items = &openapi3.SchemaRef{
+				Ref: fmt.Sprintf("#/components/schemas/%s", name),
+			}
+		}
+	}
+}
+
+// connectionPath returns the path of the resource connected by v, a
+// connection field of rsc. Connection paths starting with a dot are relative
+// to rsc.
+func connectionPath(rsc *resource.Resource, v *schema.Connection) string {
+	if strings.HasPrefix(v.Path, ".") {
+		return rsc.Path() + v.Path
+	}
+	return v.Path
+}
+
+// withSubResources returns s, the schema of rsc, a sub-resource of the parents
+// resources, with the connection fields rest-layer adds for each of its
+// included sub-resources.
+func (g *generator) withSubResources(parents []*resource.Resource, rsc *resource.Resource, s schema.Schema) schema.Schema {
+	fields := schema.Fields{}
+	for fieldName, f := range s.Fields {
+		fields[fieldName] = f
+	}
+	for _, subRsc := range rsc.GetResources() {
+		if _, found := fields[subRsc.Name()]; found {
+			continue
+		}
+		if !g.includeResource(append(parents, rsc), subRsc) || !g.isModeAllowed(subRsc, resource.List) {
+			continue
+		}
+		if field := rsc.Validator().GetField(subRsc.Name()); field != nil {
+			fields[subRsc.Name()] = *field
+		}
+	}
+	s.Fields = fields
+
+	return s
+}
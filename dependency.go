@@ -0,0 +1,31 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+// describeDependency documents the predicate an item must match for the field
+// to be changed, in its description and in the x-rest-layer-dependency
+// extension.
+func describeDependency(f schema.Field, s *openapi3.Schema) {
+	if f.Dependency == nil {
+		return
+	}
+	// query.Predicate, the usual implementation of schema.Predicate, is a
+	// fmt.Stringer rendering the predicate in the filter syntax.
+	predicate := fmt.Sprint(f.Dependency)
+
+	description := fmt.Sprintf("Can only be changed when the item matches `%s`.", predicate)
+	if s.Description != "" {
+		description = s.Description + " " + description
+	}
+	s.Description = description
+
+	if s.Extensions == nil {
+		s.Extensions = map[string]interface{}{}
+	}
+	s.Extensions["x-rest-layer-dependency"] = predicate
+}
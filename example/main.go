@@ -4,6 +4,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"net/url"
 
 	"github.com/rs/rest-layer/resource/testing/mem"
@@ -123,10 +124,13 @@ func main() {
 	posts.Alias("public", url.Values{"filter": []string{"{\"published\":true}"}})
 
 
-	doc := openapi.NewOpenapiFromIndex(index, &openapi3.Info{
+	doc, err := openapi.NewOpenapiFromIndex(index, &openapi3.Info{
 		Title:   "ApiName",
 		Version: "ApiVersion",
 	})
+	if err != nil {
+		log.Fatal(err)
+	}
 	b, _ := doc.MarshalJSON()
 	fmt.Println(string(b))
 }
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rs/rest-layer/schema"
+)
+
+// exampleTime is the time used in examples of schema.Time fields.
+var exampleTime = time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+// exampleFromSchema synthesizes an example item of s. When forRequest is true,
+// read only fields are left out and times use the first accepted layout.
+func exampleFromSchema(s schema.Schema, forRequest bool) map[string]interface{} {
+	ret := map[string]interface{}{}
+	for fieldName, field := range s.Fields {
+		if forRequest && field.ReadOnly {
+			continue
+		}
+		if _, ok := field.Validator.(*schema.Password); ok && !forRequest {
+			continue
+		}
+		// Times are returned in RFC 3339, but may only be accepted in other
+		// layouts.
+		if v, ok := field.Validator.(*schema.Time); ok && forRequest && field.Default == nil && !acceptsRFC3339(v) {
+			ret[fieldName] = exampleTime.Format(v.TimeLayouts[0])
+			continue
+		}
+		if example := exampleFromField(field); example != nil {
+			ret[fieldName] = example
+		}
+	}
+	return ret
+}
+
+// exampleFromField synthesizes an example value of a field from its default
+// value or validator, or returns nil if none can be found.
+func exampleFromField(f schema.Field) interface{} {
+	if f.Default != nil {
+		return f.Default
+	}
+
+	switch v := f.Validator.(type) {
+	case *schema.String:
+		return exampleFromString(v)
+	case *schema.Integer:
+		if len(v.Allowed) > 0 {
+			return v.Allowed[0]
+		}
+		if v.Boundaries != nil {
+			return int(math.Ceil(exampleFromBoundaries(v.Boundaries)))
+		}
+		return 1
+	case *schema.Float:
+		if len(v.Allowed) > 0 {
+			return v.Allowed[0]
+		}
+		if v.Boundaries != nil {
+			return exampleFromBoundaries(v.Boundaries)
+		}
+		return 1.5
+	case *schema.Bool:
+		return true
+	case *schema.Password:
+		return exampleFromString(&schema.String{MinLen: v.MinLen, MaxLen: v.MaxLen})
+	case *schema.Time:
+		return exampleTime.Format(time.RFC3339)
+	case *schema.Array:
+		item := exampleFromField(v.Values)
+		if item == nil {
+			return nil
+		}
+		n := v.MinLen
+		if n < 1 {
+			n = 1
+		}
+		ret := make([]interface{}, n)
+		for i := range ret {
+			ret[i] = item
+		}
+		return ret
+	case *schema.Object:
+		if v.Schema == nil {
+			return nil
+		}
+		return exampleFromSchema(*v.Schema, false)
+	default:
+		return nil
+	}
+}
+
+func exampleFromString(v *schema.String) string {
+	if len(v.Allowed) > 0 {
+		return v.Allowed[0]
+	}
+	if v.Regexp != "" {
+		if re, err := syntax.Parse(v.Regexp, syntax.Perl); err == nil {
+			return exampleFromRegexpWithLen(v.Regexp, exampleFromRegexp(re.Simplify()), v.MinLen, v.MaxLen)
+		}
+	}
+	ret := "string"
+	if len(ret) < v.MinLen {
+		ret = ret + strings.Repeat("s", v.MinLen-len(ret))
+	}
+	if v.MaxLen > 0 && len(ret) > v.MaxLen {
+		ret = ret[:v.MaxLen]
+	}
+	return ret
+}
+
+// exampleFromRegexpWithLen returns ex, an example of pattern, padded or trimmed
+// to fit the length limits of the field when it still matches pattern once
+// fitted. As for rest-layer, lengths are in bytes.
+func exampleFromRegexpWithLen(pattern, ex string, minLen, maxLen int) string {
+	if len(ex) >= minLen && (maxLen <= 0 || len(ex) <= maxLen) {
+		return ex
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return ex
+	}
+
+	// Pad by repeating the last rune, which keeps matching patterns ending
+	// with a repeated class, or trim.
+	fitted := ex
+	if len(fitted) < minLen {
+		pad := "a"
+		if r := []rune(fitted); len(r) > 0 {
+			pad = string(r[len(r)-1])
+		}
+		fitted = fitted + strings.Repeat(pad, (minLen-len(fitted)+len(pad)-1)/len(pad))
+	}
+	if maxLen > 0 && len(fitted) > maxLen {
+		fitted = fitted[:maxLen]
+	}
+	if re.MatchString(fitted) {
+		return fitted
+	}
+	return ex
+}
+
+// exampleFromBoundaries returns the middle of b, or its finite bound when the
+// other one is infinite.
+func exampleFromBoundaries(b *schema.Boundaries) float64 {
+	switch {
+	case !math.IsInf(b.Min, 0) && !math.IsInf(b.Max, 0):
+		return b.Min + (b.Max-b.Min)/2
+	case !math.IsInf(b.Min, 0):
+		return b.Min
+	case !math.IsInf(b.Max, 0):
+		return b.Max
+	default:
+		return 0
+	}
+}
+
+// exampleFromRegexp returns the shortest string matching re, taking the
+// first alternative and the first rune of each character class.
+func exampleFromRegexp(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return string(re.Rune)
+	case syntax.OpCharClass:
+		return exampleFromCharClass(re.Rune)
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return "a"
+	case syntax.OpCapture:
+		return exampleFromRegexp(re.Sub[0])
+	case syntax.OpPlus:
+		return exampleFromRegexp(re.Sub[0])
+	case syntax.OpRepeat:
+		return strings.Repeat(exampleFromRegexp(re.Sub[0]), re.Min)
+	case syntax.OpConcat:
+		var ret strings.Builder
+		for _, sub := range re.Sub {
+			ret.WriteString(exampleFromRegexp(sub))
+		}
+		return ret.String()
+	case syntax.OpAlternate:
+		return exampleFromRegexp(re.Sub[0])
+	default:
+		// Empty matches, anchors, star and quest operators.
+		return ""
+	}
+}
+
+// exampleFromCharClass returns the first printable rune of the ranges of a
+// character class, so that negated classes like [^/] don't yield U+0000.
+func exampleFromCharClass(ranges []rune) string {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1]; r++ {
+			if unicode.IsGraphic(r) && !unicode.IsSpace(r) {
+				return string(r)
+			}
+		}
+	}
+	if len(ranges) > 0 {
+		return string(ranges[0])
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/rs/rest-layer/schema"
+)
+
+func TestExampleFromRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{`^[0-9a-z]{2,20}$`, "00"},
+		{`^[0-9a-v]{20}$`, "00000000000000000000"},
+		{`^[^/]+$`, "!"},
+		{`^abc$`, "abc"},
+		{`a(b|c)+d?`, "ab"},
+		{`^\d{3}-\w+$`, "000-0"},
+		{`^x*$`, ""},
+		{`^.{2}$`, "aa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			re, err := syntax.Parse(tt.pattern, syntax.Perl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := exampleFromRegexp(re.Simplify())
+			if got != tt.want {
+				t.Errorf("exampleFromRegexp(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+			if !regexp.MustCompile(tt.pattern).MatchString(got) {
+				t.Errorf("exampleFromRegexp(%q) = %q, which doesn't match", tt.pattern, got)
+			}
+		})
+	}
+}
+
+func TestExampleFromString(t *testing.T) {
+	tests := []struct {
+		name string
+		v    schema.String
+		want string
+	}{
+		{"default", schema.String{}, "string"},
+		{"padded", schema.String{MinLen: 8}, "stringss"},
+		{"trimmed", schema.String{MaxLen: 3}, "str"},
+		{"regexp", schema.String{Regexp: `^[a-z]+$`}, "a"},
+		{"regexp padded", schema.String{Regexp: `^[a-z]+$`, MinLen: 3}, "aaa"},
+		{"regexp trimmed", schema.String{Regexp: `^[0-9]{2,20}$`, MaxLen: 1}, "00"},
+		{"regexp fixed", schema.String{Regexp: `^ab$`, MinLen: 3}, "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exampleFromString(&tt.v); got != tt.want {
+				t.Errorf("exampleFromString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
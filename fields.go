@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/schema"
+)
+
+// addFieldsParameter registers the fields query parameter of rsc with schema
+// s, describing its selectable and embeddable fields, including the
+// connections to its sub-resources, and their parameters, and returns the name
+// of the parameter.
+func (g *generator) addFieldsParameter(rsc *resource.Resource, s schema.Schema, schemaNameSingular string) string {
+	var selectable, embeddable, params []string
+
+	fieldNames := make([]string, 0, len(s.Fields))
+	for fieldName := range s.Fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		field := s.Fields[fieldName]
+		if field.Hidden && g.hiddenFields != HiddenIncluded {
+			continue
+		}
+		selectable = append(selectable, fmt.Sprintf("`%s`", fieldName))
+
+		switch v := field.Validator.(type) {
+		case *schema.Reference:
+			embeddable = append(embeddable, fmt.Sprintf("`%s` (%s)", fieldName, v.Path))
+		case *schema.Connection:
+			embeddable = append(embeddable, fmt.Sprintf("`%s` (%s)", fieldName, connectionPath(rsc, v)))
+		}
+
+		paramNames := make([]string, 0, len(field.Params))
+		for paramName := range field.Params {
+			paramNames = append(paramNames, paramName)
+		}
+		sort.Strings(paramNames)
+		for _, paramName := range paramNames {
+			param := field.Params[paramName]
+			paramType := "any"
+			if param.Validator != nil {
+				if ps := g.generateSchemaFromField(schema.Field{Validator: param.Validator}); ps != nil && ps.Type != "" {
+					paramType = ps.Type
+				}
+			}
+			desc := fmt.Sprintf("`%s(%s)` (%s)", fieldName, paramName, paramType)
+			if param.Description != "" {
+				desc = desc + ": " + param.Description
+			}
+			params = append(params, desc)
+		}
+	}
+
+	description := "[Select](http://rest-layer.io/#field-selection) which fields to show, optionally [aliased](http://rest-layer.io/#field-aliasing) as `alias:field`."
+	if len(selectable) > 0 {
+		description += "\n\nSelectable fields: " + strings.Join(selectable, ", ") + "."
+	}
+	if len(embeddable) > 0 {
+		description += "\n\n[Embeddable](http://rest-layer.io/#embedding) fields, selecting their own fields as `field{subfield}`: " + strings.Join(embeddable, ", ") + "."
+	}
+	if len(params) > 0 {
+		description += "\n\n[Field parameters](http://rest-layer.io/#field-parameters):\n- " + strings.Join(params, "\n- ")
+	}
+
+	fieldsParameter := schemaNameSingular + "Fields"
+	g.doc.Components.Parameters[fieldsParameter] = &openapi3.ParameterRef{
+		Value: &openapi3.Parameter{
+			Description: description,
+			Name:        "fields",
+			In:          "query",
+			Schema: &openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type: "string",
+				},
+			},
+		},
+	}
+
+	return fieldsParameter
+}
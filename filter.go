@@ -0,0 +1,186 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/schema"
+)
+
+// Filter selects the parts of an index documented by the generated spec, so
+// that several audience specific specs can be generated from the same index.
+// Nil predicates include everything.
+type Filter struct {
+	// Resource returns false for resources to leave out, along with their
+	// sub-resources.
+	Resource func(rsc *resource.Resource) bool
+	// Tag returns false for the tags of resources to leave out, along with
+	// their sub-resources.
+	Tag func(tag string) bool
+	// KeepTag returns true for the tags of resources to keep, along with their
+	// parents and sub-resources. Resources related to no kept tag are left
+	// out.
+	KeepTag func(tag string) bool
+	// Mode returns false for the operations to leave out.
+	Mode func(rsc *resource.Resource, mode resource.Mode) bool
+	// Field returns false for the fields to leave out.
+	Field func(rsc *resource.Resource, fieldName string, field schema.Field) bool
+	// Alias returns false for the aliases to leave out.
+	Alias func(rsc *resource.Resource, alias string) bool
+}
+
+// WithFilter restricts the document to the parts of the index accepted by f.
+// When several filters are given, all of them must accept a part for it to be
+// documented.
+func WithFilter(f Filter) Option {
+	return func(g *generator) {
+		g.filters = append(g.filters, f)
+	}
+}
+
+// IncludePaths returns a filter keeping only the resources at the given paths
+// (i.e. "users.posts"), their parents and their sub-resources.
+func IncludePaths(paths ...string) Filter {
+	return Filter{
+		Resource: func(rsc *resource.Resource) bool {
+			for _, path := range paths {
+				if isPathRelated(rsc.Path(), path) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// ExcludePaths returns a filter leaving out the resources at the given paths
+// and their sub-resources.
+func ExcludePaths(paths ...string) Filter {
+	return Filter{
+		Resource: func(rsc *resource.Resource) bool {
+			for _, path := range paths {
+				if rsc.Path() == path || strings.HasPrefix(rsc.Path(), path+".") {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// IncludeTags returns a filter keeping only the resources with the given tags,
+// their parents and their sub-resources.
+func IncludeTags(tags ...string) Filter {
+	return Filter{
+		KeepTag: func(tag string) bool {
+			return containsString(tags, tag)
+		},
+	}
+}
+
+// ExcludeTags returns a filter leaving out the resources with the given tags.
+func ExcludeTags(tags ...string) Filter {
+	return Filter{
+		Tag: func(tag string) bool {
+			return !containsString(tags, tag)
+		},
+	}
+}
+
+// isPathRelated tells if path is equal to, a parent of, or a sub-resource of
+// other.
+func isPathRelated(path, other string) bool {
+	return path == other ||
+		strings.HasPrefix(path, other+".") ||
+		strings.HasPrefix(other, path+".")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// includeResource tells if rsc, a sub-resource of the parents resources, is
+// included by the filters.
+func (g *generator) includeResource(parents []*resource.Resource, rsc *resource.Resource) bool {
+	for _, f := range g.filters {
+		if f.Resource != nil && !f.Resource(rsc) {
+			return false
+		}
+		if f.Tag != nil && !f.Tag(g.tagNamer(rsc)) {
+			return false
+		}
+		if f.KeepTag != nil && !g.isTagRelated(parents, rsc, f.KeepTag) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTagRelated tells if keep accepts the tag of rsc, of one of its parents or
+// of one of its sub-resources.
+func (g *generator) isTagRelated(parents []*resource.Resource, rsc *resource.Resource, keep func(tag string) bool) bool {
+	for _, parent := range parents {
+		if keep(g.tagNamer(parent)) {
+			return true
+		}
+	}
+	related := keep(g.tagNamer(rsc))
+	walkResources(rsc.GetResources(), func(subRsc *resource.Resource) {
+		related = related || keep(g.tagNamer(subRsc))
+	})
+	return related
+}
+
+// isModeAllowed tells if the operation handling mode on rsc is both allowed by
+// the resource configuration and included by the filters.
+func (g *generator) isModeAllowed(rsc *resource.Resource, mode resource.Mode) bool {
+	if !rsc.Conf().IsModeAllowed(mode) {
+		return false
+	}
+	for _, f := range g.filters {
+		if f.Mode != nil && !f.Mode(rsc, mode) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *generator) includeAlias(rsc *resource.Resource, alias string) bool {
+	for _, f := range g.filters {
+		if f.Alias != nil && !f.Alias(rsc, alias) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterSchema returns the schema of rsc restricted to the fields included by
+// the filters.
+func (g *generator) filterSchema(rsc *resource.Resource) schema.Schema {
+	s := rsc.Schema()
+	if len(g.filters) == 0 {
+		return s
+	}
+
+	fields := schema.Fields{}
+	for fieldName, field := range s.Fields {
+		included := true
+		for _, f := range g.filters {
+			if f.Field != nil && !f.Field(rsc, fieldName, field) {
+				included = false
+				break
+			}
+		}
+		if included {
+			fields[fieldName] = field
+		}
+	}
+	s.Fields = fields
+
+	return s
+}
@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/schema"
+)
+
+// graphqlListArguments are the arguments of the fields listing the items of a
+// resource.
+const graphqlListArguments = "(skip: Int, page: Int, limit: Int, filter: String, sort: String)"
+
+// NewGraphQLSchemaFromIndex returns the GraphQL SDL document of index, as
+// exposed by the rest-layer graphql package: one object type per resource,
+// named after the resource, and a RootQuery type getting each top-level
+// resource by id and listing its items and aliases. Filters apply as for
+// NewOpenapiFromIndex. Hidden fields are always left out.
+//
+// The document deliberately doesn't reuse the validator mapping of the
+// OpenAPI document: field types follow the rest-layer graphql package, which
+// serves the schema, so schema names, OpenAPISchemer validators and
+// WithValidatorSchema have no effect on it.
+func NewGraphQLSchemaFromIndex(index resource.Index, opts ...Option) string {
+	g := newGenerator(&openapi3.Swagger{Components: newComponents()}, opts)
+
+	t := &graphqlTypes{
+		g:        g,
+		index:    index,
+		included: map[string]bool{},
+		defs:     map[string]string{},
+	}
+	var include func(parents, rscList []*resource.Resource)
+	include = func(parents, rscList []*resource.Resource) {
+		for _, rsc := range rscList {
+			if g.includeResource(parents, rsc) {
+				t.included[rsc.Path()] = true
+				include(append(parents, rsc), rsc.GetResources())
+			}
+		}
+	}
+	include(nil, index.GetResources())
+
+	var query []string
+	for _, rsc := range index.GetResources() {
+		if !t.included[rsc.Path()] {
+			continue
+		}
+		if g.isModeAllowed(rsc, resource.Read) {
+			query = append(query, graphqlField(
+				fmt.Sprintf("Get %s by id", rsc.Name()),
+				rsc.Name()+"(id: String)",
+				t.objectType(rsc),
+			))
+		}
+		if g.isModeAllowed(rsc, resource.List) {
+			listType := "[" + t.objectType(rsc) + "]"
+			query = append(query, graphqlField(
+				fmt.Sprintf("Get a list of %s", rsc.Name()),
+				rsc.Name()+"List"+graphqlListArguments,
+				listType,
+			))
+
+			aliases := rsc.GetAliases()
+			sort.Strings(aliases)
+			for _, alias := range aliases {
+				if !g.includeAlias(rsc, alias) {
+					continue
+				}
+				query = append(query, graphqlField(
+					fmt.Sprintf("Get a list of %s", rsc.Name()),
+					rsc.Name()+strings.Title(alias)+graphqlListArguments,
+					listType,
+				))
+			}
+		}
+	}
+
+	var sdl []string
+	sdl = append(sdl, "schema {\n  query: RootQuery\n}")
+	for _, name := range t.names {
+		sdl = append(sdl, t.defs[name])
+	}
+	sdl = append(sdl, graphqlObject("", "RootQuery", query))
+
+	return strings.Join(sdl, "\n\n") + "\n"
+}
+
+// graphqlTypes collects the object types of a GraphQL document. As in the
+// rest-layer graphql package, types are memoized by name, so that resources
+// and nested objects sharing a name share the type of the first one.
+type graphqlTypes struct {
+	g     *generator
+	index resource.Index
+	// included holds the paths of the resources included by the filters.
+	included map[string]bool
+	names    []string
+	defs     map[string]string
+}
+
+// objectType returns the name of the object type of rsc, adding it and the
+// types it uses if needed.
+func (t *graphqlTypes) objectType(rsc *resource.Resource) string {
+	name := rsc.Name()
+	if _, found := t.defs[name]; found {
+		return name
+	}
+	// Reserve the place of the type so that it precedes the types it uses,
+	// and so that references back to it don't loop.
+	t.names = append(t.names, name)
+	t.defs[name] = ""
+
+	fields := t.fields(t.g.filterSchema(rsc))
+	for _, subRsc := range rsc.GetResources() {
+		if !t.included[subRsc.Path()] {
+			continue
+		}
+		fields = append(fields, graphqlField(
+			fmt.Sprintf("Connection to %s", subRsc.Name()),
+			subRsc.Name()+graphqlListArguments,
+			"["+t.objectType(subRsc)+"]",
+		))
+	}
+
+	t.defs[name] = graphqlObject(rsc.Schema().Description, name, fields)
+	return name
+}
+
+// nestedType returns the name of the object type of a field with a nested
+// schema s, named after the field.
+func (t *graphqlTypes) nestedType(name string, s schema.Schema) string {
+	if _, found := t.defs[name]; found {
+		return name
+	}
+	t.names = append(t.names, name)
+	t.defs[name] = ""
+	t.defs[name] = graphqlObject(s.Description, name, t.fields(s))
+	return name
+}
+
+// fields returns the GraphQL fields of the visible fields of s, sorted by
+// name.
+func (t *graphqlTypes) fields(s schema.Schema) []string {
+	fieldNames := make([]string, 0, len(s.Fields))
+	for fieldName := range s.Fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	var fields []string
+	for _, fieldName := range fieldNames {
+		field := s.Fields[fieldName]
+		if field.Hidden {
+			continue
+		}
+
+		fieldType := graphqlScalar(field.Validator)
+		if ref, ok := field.Validator.(*schema.Reference); ok && t.included[ref.Path] {
+			if refRsc, found := t.index.GetResource(ref.Path, nil); found {
+				fieldType = t.objectType(refRsc)
+			}
+		} else if field.Schema != nil {
+			fieldType = t.nestedType(fieldName, *field.Schema)
+		}
+
+		fields = append(fields, graphqlField(
+			field.Description,
+			fieldName+graphqlArguments(field.Params),
+			fieldType,
+		))
+	}
+	return fields
+}
+
+// graphqlArguments returns the arguments of a field with params, sorted by
+// name.
+func graphqlArguments(params schema.Params) string {
+	if len(params) == 0 {
+		return ""
+	}
+	paramNames := make([]string, 0, len(params))
+	for paramName := range params {
+		paramNames = append(paramNames, paramName)
+	}
+	sort.Strings(paramNames)
+
+	args := make([]string, 0, len(paramNames))
+	for _, paramName := range paramNames {
+		args = append(args, paramName+": "+graphqlScalar(params[paramName].Validator))
+	}
+	return "(" + strings.Join(args, ", ") + ")"
+}
+
+// graphqlScalar returns the GraphQL type of fields validated by v, mapped as
+// the rest-layer graphql package does: String, Int, Float and Boolean
+// validators to their GraphQL counterpart, and anything else to String.
+func graphqlScalar(v schema.FieldValidator) string {
+	switch v.(type) {
+	case *schema.Integer, schema.Integer:
+		return "Int"
+	case *schema.Float, schema.Float:
+		return "Float"
+	case *schema.Bool, schema.Bool:
+		return "Boolean"
+	default:
+		return "String"
+	}
+}
+
+// graphqlField returns the SDL of a field declared as decl (its name and
+// arguments) with type typ, preceded by its description if any.
+func graphqlField(description, decl, typ string) string {
+	field := fmt.Sprintf("  %s: %s", decl, typ)
+	if description != "" {
+		field = "  " + strconv.Quote(description) + "\n" + field
+	}
+	return field
+}
+
+// graphqlObject returns the SDL of an object type, preceded by its description
+// if any.
+func graphqlObject(description, name string, fields []string) string {
+	object := fmt.Sprintf("type %s {\n%s\n}", name, strings.Join(fields, "\n"))
+	if description != "" {
+		object = strconv.Quote(description) + "\n" + object
+	}
+	return object
+}
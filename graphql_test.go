@@ -0,0 +1,25 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rs/rest-layer/resource"
+)
+
+func TestNewGraphQLSchemaFromIndexIgnoresSchemaNames(t *testing.T) {
+	// The namer gives both comments resources the same schema name, which
+	// only matters to the OpenAPI document.
+	sdl := NewGraphQLSchemaFromIndex(newCommentsIndex(), WithSchemaNamer(func(rsc *resource.Resource) string {
+		return rsc.Name()
+	}))
+	for _, want := range []string{
+		"type comments {",
+		"  posts(id: String): posts",
+		"  comments(skip: Int, page: Int, limit: Int, filter: String, sort: String): [comments]",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL is missing %q:\n%s", want, sdl)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+)
+
+// Authorizer tells if the caller of r is allowed to use mode on the resource
+// at path (i.e. "users.posts").
+type Authorizer func(r *http.Request, path string, mode resource.Mode) bool
+
+var allModes = []resource.Mode{
+	resource.Create,
+	resource.Read,
+	resource.Update,
+	resource.Replace,
+	resource.Delete,
+	resource.Clear,
+	resource.List,
+}
+
+type handler struct {
+	index     resource.Index
+	info      *openapi3.Info
+	authorize Authorizer
+	opts      []Option
+
+	mu    sync.RWMutex
+	cache map[string][]byte
+}
+
+// NewHandler returns an http.Handler serving the document of index, restricted
+// to the operations authorize allows for each request. Documents are cached
+// by set of permissions.
+func NewHandler(index resource.Index, info *openapi3.Info, authorize Authorizer, opts ...Option) http.Handler {
+	return &handler{
+		index:     index,
+		info:      info,
+		authorize: authorize,
+		opts:      opts,
+		cache:     map[string][]byte{},
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	allowed := map[string]map[resource.Mode]bool{}
+	var key strings.Builder
+	walkResources(h.index.GetResources(), func(rsc *resource.Resource) {
+		allowed[rsc.Path()] = map[resource.Mode]bool{}
+		for _, mode := range allModes {
+			if rsc.Conf().IsModeAllowed(mode) && h.authorize(r, rsc.Path(), mode) {
+				allowed[rsc.Path()][mode] = true
+				key.WriteByte('1')
+			} else {
+				key.WriteByte('0')
+			}
+		}
+	})
+
+	b, err := h.document(key.String(), allowed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// document returns the cached document for the permission set identified by
+// key, generating it if needed.
+func (h *handler) document(key string, allowed map[string]map[resource.Mode]bool) ([]byte, error) {
+	h.mu.RLock()
+	b, found := h.cache[key]
+	h.mu.RUnlock()
+	if found {
+		return b, nil
+	}
+
+	opts := append([]Option{}, h.opts...)
+	opts = append(opts, WithFilter(Filter{
+		// Resources with no allowed operation are only documented when one of
+		// their sub-resources has some.
+		Resource: func(rsc *resource.Resource) bool {
+			for path, modes := range allowed {
+				if len(modes) > 0 && (path == rsc.Path() || strings.HasPrefix(path, rsc.Path()+".")) {
+					return true
+				}
+			}
+			return false
+		},
+		Mode: func(rsc *resource.Resource, mode resource.Mode) bool {
+			return allowed[rsc.Path()][mode]
+		},
+	}))
+
+	doc, err := NewOpenapiFromIndex(h.index, h.info, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b, err = doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	h.cache[key] = b
+	h.mu.Unlock()
+
+	return b, nil
+}
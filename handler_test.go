@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/resource/testing/mem"
+	"github.com/rs/rest-layer/schema"
+)
+
+func newUsersIndex() resource.Index {
+	s := schema.Schema{Fields: schema.Fields{
+		"id":   schema.IDField,
+		"user": {Validator: &schema.String{}},
+	}}
+	index := resource.NewIndex()
+	users := index.Bind("users", s, mem.NewHandler(), resource.DefaultConf)
+	users.Bind("posts", "user", s, mem.NewHandler(), resource.DefaultConf)
+	index.Bind("groups", s, mem.NewHandler(), resource.DefaultConf)
+	return index
+}
+
+// authorizeRole allows admins everything, and others only to list posts.
+func authorizeRole(r *http.Request, path string, mode resource.Mode) bool {
+	if r.Header.Get("Role") == "admin" {
+		return true
+	}
+	return path == "users.posts" && mode == resource.List
+}
+
+func serveDocument(t *testing.T, h http.Handler, role string) *openapi3.Swagger {
+	t.Helper()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Role", role)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	doc := &openapi3.Swagger{}
+	if err := doc.UnmarshalJSON(w.Body.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func documentPaths(doc *openapi3.Swagger) string {
+	var paths []string
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return strings.Join(paths, " ")
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(newUsersIndex(), &openapi3.Info{Title: "test"}, authorizeRole)
+
+	admin := serveDocument(t, h, "admin")
+	want := "/groups /groups/{groupId} /users /users/{userId} /users/{userId}/posts /users/{userId}/posts/{postId}"
+	if got := documentPaths(admin); got != want {
+		t.Errorf("admin paths = %q, want %q", got, want)
+	}
+
+	user := serveDocument(t, h, "user")
+	if got, want := documentPaths(user), "/users/{userId}/posts"; got != want {
+		t.Errorf("user paths = %q, want %q", got, want)
+	}
+	// users has no allowed mode, but its id parameter is used by the path of
+	// posts.
+	if user.Components.Parameters["userId"] == nil {
+		t.Error("user document is missing the userId parameter")
+	}
+	if user.Components.Schemas["user"] == nil {
+		t.Error("user document is missing the user schema the userId parameter refers to")
+	}
+	if user.Components.Schemas["group"] != nil {
+		t.Error("user document has the schema of groups, which has no allowed mode")
+	}
+
+	cache := h.(*handler).cache
+	if got := len(cache); got != 2 {
+		t.Fatalf("cached documents = %d, want 2, one per permission set", got)
+	}
+	for key := range cache {
+		cache[key] = []byte(`{"info":{"title":"cached"}}`)
+	}
+	if got := serveDocument(t, h, "user").Info.Title; got != "cached" {
+		t.Errorf("title = %q, want the cached document", got)
+	}
+	if got := len(cache); got != 2 {
+		t.Errorf("cached documents = %d, want 2 after serving a cached document", got)
+	}
+}
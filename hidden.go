@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+// HiddenFieldsMode defines how schema.Field.Hidden fields, which rest-layer
+// never returns, are documented.
+type HiddenFieldsMode int
+
+const (
+	// HiddenWriteOnly documents hidden fields as writeOnly, so they only
+	// appear in request schemas. This is the default.
+	HiddenWriteOnly HiddenFieldsMode = iota
+	// HiddenOmitted leaves hidden fields out of the document.
+	HiddenOmitted
+	// HiddenIncluded documents hidden fields like any other field, for
+	// internal audiences.
+	HiddenIncluded
+)
+
+// WithHiddenFields sets how hidden fields are documented.
+func WithHiddenFields(mode HiddenFieldsMode) Option {
+	return func(g *generator) {
+		g.hiddenFields = mode
+	}
+}
+
+// applyHiddenFields documents the hidden fields of s in its generated schema
+// ret and request example according to the configured mode.
+func (g *generator) applyHiddenFields(s schema.Schema, ret *openapi3.Schema, requestExample map[string]interface{}) {
+	if g.hiddenFields == HiddenIncluded {
+		return
+	}
+
+	responseExample, _ := ret.Example.(map[string]interface{})
+
+	for fieldName, field := range s.Fields {
+		if !field.Hidden {
+			continue
+		}
+		delete(responseExample, fieldName)
+		switch g.hiddenFields {
+		case HiddenWriteOnly:
+			if prop := ret.Properties[fieldName]; prop != nil && prop.Value != nil {
+				prop.Value.WriteOnly = true
+			}
+		case HiddenOmitted:
+			delete(ret.Properties, fieldName)
+			delete(requestExample, fieldName)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/schema"
+)
+
+// OperationHook is implemented by hooks customizing the operations generated
+// for each allowed mode of a resource.
+type OperationHook interface {
+	OnOperation(rsc *resource.Resource, path string, mode resource.Mode, op *openapi3.Operation)
+}
+
+// SchemaHook is implemented by hooks customizing the schema generated for
+// each field of a resource. It is also invoked for the resource schema itself
+// with an empty fieldName.
+type SchemaHook interface {
+	OnSchema(rsc *resource.Resource, fieldName string, field schema.Field, s *openapi3.Schema)
+}
+
+// PathHook is implemented by hooks customizing the collection and item paths
+// generated for a resource, once all their operations are added.
+type PathHook interface {
+	OnPath(rsc *resource.Resource, path string, item *openapi3.PathItem)
+}
+
+// WithHook registers a hook implementing any of OperationHook, SchemaHook or
+// PathHook. Hooks are invoked in registration order.
+func WithHook(hook interface{}) Option {
+	return func(g *generator) {
+		g.hooks = append(g.hooks, hook)
+	}
+}
+
+// addOperation adds the operation handling mode on rsc to the document.
+func (g *generator) addOperation(rsc *resource.Resource, path, method string, mode resource.Mode, op *openapi3.Operation) {
+	op.Security = g.operationSecurity(rsc, mode)
+	for _, hook := range g.hooks {
+		if h, ok := hook.(OperationHook); ok {
+			h.OnOperation(rsc, path, mode, op)
+		}
+	}
+	g.registerOperationID(op.OperationID, method, path)
+	g.doc.AddOperation(path, method, op)
+}
+
+// runSchemaHooks calls the schema hooks on s, generated from def, the schema
+// of rsc as documented, and on the properties of its fields.
+func (g *generator) runSchemaHooks(rsc *resource.Resource, def schema.Schema, s *openapi3.Schema) {
+	for _, hook := range g.hooks {
+		h, ok := hook.(SchemaHook)
+		if !ok {
+			continue
+		}
+		h.OnSchema(rsc, "", schema.Field{}, s)
+		for fieldName, field := range def.Fields {
+			if prop, found := s.Properties[fieldName]; found && prop.Value != nil {
+				h.OnSchema(rsc, fieldName, field, prop.Value)
+			}
+		}
+	}
+}
+
+func (g *generator) runPathHooks(rsc *resource.Resource, paths ...string) {
+	for _, hook := range g.hooks {
+		h, ok := hook.(PathHook)
+		if !ok {
+			continue
+		}
+		for _, path := range paths {
+			if item := g.doc.Paths[path]; item != nil {
+				h.OnPath(rsc, path, item)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"github.com/rs/rest-layer/resource"
+)
+
+// WithSchemaNamer sets the function naming the schema of each resource, also
+// used as prefix of its id parameter. By default, the singular of the
+// resource name is used (i.e. "comment"), unless several resources share it,
+// in which case the singular of each element of the resource path is used
+// (i.e. "postComment" and "photoComment").
+func WithSchemaNamer(namer func(rsc *resource.Resource) string) Option {
+	return func(g *generator) {
+		g.schemaNamer = namer
+	}
+}
+
+// resolveSchemaNames names the schema of every resource of rscList and their
+// sub-resources, and returns an error if a name is empty or if two of them get
+// the same name.
+func (g *generator) resolveSchemaNames(rscList []*resource.Resource) error {
+	g.schemaNames = map[string]string{}
+
+	namer := g.schemaNamer
+	if namer == nil {
+		counts := map[string]int{}
+		walkResources(rscList, func(rsc *resource.Resource) {
+			counts[inflection.Singular(rsc.Name())]++
+		})
+		namer = func(rsc *resource.Resource) string {
+			if counts[inflection.Singular(rsc.Name())] > 1 {
+				return pathSchemaName(rsc.Path())
+			}
+			return inflection.Singular(rsc.Name())
+		}
+	}
+
+	paths := map[string]string{}
+	var err error
+	walkResources(rscList, func(rsc *resource.Resource) {
+		name := namer(rsc)
+		if name == "" && err == nil {
+			err = fmt.Errorf("empty schema name for %s", rsc.Path())
+		}
+		if other, found := paths[name]; found && err == nil {
+			err = fmt.Errorf("schema name %q used by both %s and %s", name, other, rsc.Path())
+		}
+		paths[name] = rsc.Path()
+		g.schemaNames[rsc.Path()] = name
+	})
+
+	return err
+}
+
+// pathSchemaName returns the lower camel case concatenation of the singular of
+// each element of a resource path (i.e. "postComment" for "posts.comments").
+func pathSchemaName(path string) string {
+	var name string
+	for i, elem := range strings.Split(path, ".") {
+		elem = inflection.Singular(elem)
+		if i > 0 {
+			elem = strings.Title(elem)
+		}
+		name = name + elem
+	}
+	return name
+}
+
+func walkResources(rscList []*resource.Resource, fn func(rsc *resource.Resource)) {
+	for _, rsc := range rscList {
+		fn(rsc)
+		walkResources(rsc.GetResources(), fn)
+	}
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/resource/testing/mem"
+	"github.com/rs/rest-layer/schema"
+)
+
+func newCommentsIndex() resource.Index {
+	s := schema.Schema{Fields: schema.Fields{
+		"id":   schema.IDField,
+		"item": {Validator: &schema.String{}},
+	}}
+	index := resource.NewIndex()
+	posts := index.Bind("posts", s, mem.NewHandler(), resource.DefaultConf)
+	posts.Bind("comments", "item", s, mem.NewHandler(), resource.DefaultConf)
+	photos := index.Bind("photos", s, mem.NewHandler(), resource.DefaultConf)
+	photos.Bind("comments", "item", s, mem.NewHandler(), resource.DefaultConf)
+	return index
+}
+
+func TestResolveSchemaNames(t *testing.T) {
+	g := &generator{}
+	if err := g.resolveSchemaNames(newCommentsIndex().GetResources()); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"posts":           "post",
+		"posts.comments":  "postComment",
+		"photos":          "photo",
+		"photos.comments": "photoComment",
+	}
+	for path, name := range want {
+		if got := g.schemaNames[path]; got != name {
+			t.Errorf("schema name of %s = %q, want %q", path, got, name)
+		}
+	}
+}
+
+func TestResolveSchemaNamesCollision(t *testing.T) {
+	g := &generator{
+		schemaNamer: func(rsc *resource.Resource) string {
+			return rsc.Name()
+		},
+	}
+	err := g.resolveSchemaNames(newCommentsIndex().GetResources())
+	if err == nil {
+		t.Fatal("expected an error for the comments schema name used twice")
+	}
+	want := `schema name "comments" used by both photos.comments and posts.comments`
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestResolveSchemaNamesEmpty(t *testing.T) {
+	g := &generator{
+		schemaNamer: func(rsc *resource.Resource) string {
+			if rsc.Path() == "photos" {
+				return ""
+			}
+			return rsc.Path()
+		},
+	}
+	err := g.resolveSchemaNames(newCommentsIndex().GetResources())
+	if err == nil {
+		t.Fatal("expected an error for the empty schema name")
+	}
+	want := "empty schema name for photos"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+func generateSchemaFromFieldNull(f schema.Field) *openapi3.Schema {
+	return &openapi3.Schema{
+		Nullable: true,
+		Enum:     []interface{}{nil},
+	}
+}
+
+// generateSchemaFromFieldAnyOf returns the anyOf of the schemas of each
+// validator. schema.Null validators are rendered as nullable instead, so that
+// an AnyOf of a validator and Null becomes a nullable schema.
+func (g *generator) generateSchemaFromFieldAnyOf(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.AnyOf)
+
+	nullable := false
+	unsupported := false
+	anyOf := []*openapi3.SchemaRef{}
+	for _, validator := range *v {
+		switch validator.(type) {
+		case *schema.Null, schema.Null:
+			nullable = true
+			continue
+		}
+		if s := g.generateSchemaFromField(schema.Field{Validator: validator}); s != nil {
+			anyOf = append(anyOf, &openapi3.SchemaRef{Value: s})
+		} else {
+			unsupported = true
+		}
+	}
+
+	var ret *openapi3.Schema
+	switch {
+	case unsupported:
+		// Without the schema of every alternative, any value is documented
+		// as valid.
+		ret = &openapi3.Schema{}
+	case len(anyOf) == 0:
+		return generateSchemaFromFieldNull(f)
+	case len(anyOf) == 1:
+		ret = anyOf[0].Value
+	default:
+		ret = &openapi3.Schema{
+			AnyOf: anyOf,
+		}
+	}
+	ret.Nullable = nullable
+
+	return ret
+}
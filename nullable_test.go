@@ -0,0 +1,40 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/rs/rest-layer/schema"
+)
+
+func TestGenerateSchemaFromFieldAnyOfNull(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator schema.FieldValidator
+	}{
+		{"pointers", &schema.AnyOf{&schema.String{}, &schema.Null{}}},
+		{"values", schema.AnyOf{&schema.String{}, schema.Null{}}},
+		{"pointer of values", &schema.AnyOf{&schema.String{}, schema.Null{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &generator{}
+			s := g.generateSchemaFromField(schema.Field{Validator: tt.validator})
+			if s == nil {
+				t.Fatal("no schema generated")
+			}
+			if s.Type != "string" || !s.Nullable {
+				t.Errorf("schema = {type: %q, nullable: %v}, want {type: \"string\", nullable: true}", s.Type, s.Nullable)
+			}
+		})
+	}
+}
+
+func TestGenerateSchemaFromFieldNull(t *testing.T) {
+	for _, validator := range []schema.FieldValidator{&schema.Null{}, schema.Null{}} {
+		g := &generator{}
+		s := g.generateSchemaFromField(schema.Field{Validator: validator})
+		if s == nil || !s.Nullable {
+			t.Errorf("schema of %T = %v, want a nullable schema", validator, s)
+		}
+	}
+}
@@ -1,20 +1,79 @@
 package openapi
 
 import (
+	"reflect"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/rs/rest-layer/resource"
 )
 
-func NewOpenapiFromIndex(index resource.Index, info *openapi3.Info) *openapi3.Swagger {
+// Option customizes the document generated by NewOpenapiFromIndex.
+type Option func(*generator)
+
+type generator struct {
+	doc       *openapi3.Swagger
+	tagNamer  func(rsc *resource.Resource) string
+	tagGroups []*tagGroup
+
+	schemaNamer func(rsc *resource.Resource) string
+	schemaNames map[string]string
+
+	pathPrefix string
+
+	hiddenFields HiddenFieldsMode
+	filters      []Filter
+
+	validatorSchemas map[reflect.Type]SchemaFunc
+	annotations      map[string]map[string]FieldAnnotation
+	hooks            []interface{}
+
+	pinnedOperationIDs      map[string]map[resource.Mode]string
+	pinnedAliasOperationIDs map[string]map[string]string
+	operationIDs            map[string]string
+
+	resourceSecurity map[string]openapi3.SecurityRequirements
+	modeSecurity     map[string]map[resource.Mode]openapi3.SecurityRequirements
+
+	// err is the first error encountered while generating the document.
+	err error
+}
+
+func NewOpenapiFromIndex(index resource.Index, info *openapi3.Info, opts ...Option) (*openapi3.Swagger, error) {
 	doc := &openapi3.Swagger{
 		OpenAPI:    "3.0.0",
 		Info:       info,
-		Components: staticComponents,
+		Components: newComponents(),
+	}
+
+	g := newGenerator(doc, opts)
+	if err := g.resolveSchemaNames(index.GetResources()); err != nil {
+		return nil, err
 	}
 
 	for _, rsc := range index.GetResources() {
-		addResource(doc, []*resource.Resource{}, rsc)
+		if g.includeResource(nil, rsc) {
+			g.addResource([]*resource.Resource{}, rsc)
+		}
+	}
+
+	g.addTagGroups()
+
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	return doc, nil
+}
+
+// newGenerator returns a generator of doc configured with opts.
+func newGenerator(doc *openapi3.Swagger, opts []Option) *generator {
+	g := &generator{
+		doc:      doc,
+		tagNamer: defaultTagNamer,
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
 
-	return doc
-}
\ No newline at end of file
+	return g
+}
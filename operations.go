@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/rs/rest-layer/resource"
+)
+
+// WithOperationID pins the operationId of the operation handling mode on the
+// resource at path (i.e. "users.posts"), so that it remains stable when
+// resources are renamed or moved.
+func WithOperationID(path string, mode resource.Mode, id string) Option {
+	return func(g *generator) {
+		if g.pinnedOperationIDs == nil {
+			g.pinnedOperationIDs = map[string]map[resource.Mode]string{}
+		}
+		if g.pinnedOperationIDs[path] == nil {
+			g.pinnedOperationIDs[path] = map[resource.Mode]string{}
+		}
+		g.pinnedOperationIDs[path][mode] = id
+	}
+}
+
+// operationID returns the pinned operationId of the operation handling mode on
+// rsc, or generated otherwise.
+func (g *generator) operationID(rsc *resource.Resource, mode resource.Mode, generated string) string {
+	if id, found := g.pinnedOperationIDs[rsc.Path()][mode]; found {
+		return id
+	}
+	return generated
+}
+
+// WithAliasOperationID pins the operationId of the List operation of the alias
+// of the resource at path (i.e. "users.posts").
+func WithAliasOperationID(path, alias, id string) Option {
+	return func(g *generator) {
+		if g.pinnedAliasOperationIDs == nil {
+			g.pinnedAliasOperationIDs = map[string]map[string]string{}
+		}
+		if g.pinnedAliasOperationIDs[path] == nil {
+			g.pinnedAliasOperationIDs[path] = map[string]string{}
+		}
+		g.pinnedAliasOperationIDs[path][alias] = id
+	}
+}
+
+// aliasOperationID returns the pinned operationId of the List operation of the
+// alias of rsc, or generated otherwise.
+func (g *generator) aliasOperationID(rsc *resource.Resource, alias, generated string) string {
+	if id, found := g.pinnedAliasOperationIDs[rsc.Path()][alias]; found {
+		return id
+	}
+	return generated
+}
+
+// registerOperationID records the operationId used by the operation at method
+// and path, and reports an error if it is already used by another one.
+func (g *generator) registerOperationID(id, method, path string) {
+	if g.operationIDs == nil {
+		g.operationIDs = map[string]string{}
+	}
+	operation := method + " " + path
+	if other, found := g.operationIDs[id]; found && g.err == nil {
+		g.err = fmt.Errorf("operationId %q used by both %s and %s", id, other, operation)
+	}
+	g.operationIDs[id] = operation
+}
@@ -0,0 +1,33 @@
+package openapi
+
+import "testing"
+
+func TestRegisterOperationID(t *testing.T) {
+	g := &generator{}
+	g.registerOperationID("ListPosts", "GET", "/posts")
+	g.registerOperationID("CreatePost", "POST", "/posts")
+	if g.err != nil {
+		t.Fatalf("unexpected error for unique operationIds: %v", g.err)
+	}
+
+	g.registerOperationID("ListPosts", "GET", "/photos")
+	if g.err == nil {
+		t.Fatal("expected an error for the ListPosts operationId used twice")
+	}
+	want := `operationId "ListPosts" used by both GET /posts and GET /photos`
+	if g.err.Error() != want {
+		t.Errorf("error = %q, want %q", g.err, want)
+	}
+}
+
+func TestAliasOperationID(t *testing.T) {
+	g := &generator{}
+	WithAliasOperationID("posts", "public", "ListPublicPosts")(g)
+	rsc, _ := newCommentsIndex().GetResource("posts", nil)
+	if got := g.aliasOperationID(rsc, "public", "ListPostsPublic"); got != "ListPublicPosts" {
+		t.Errorf("pinned alias operationId = %q, want %q", got, "ListPublicPosts")
+	}
+	if got := g.aliasOperationID(rsc, "draft", "ListPostsDraft"); got != "ListPostsDraft" {
+		t.Errorf("unpinned alias operationId = %q, want %q", got, "ListPostsDraft")
+	}
+}
@@ -0,0 +1,23 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+// generateSchemaFromFieldPassword returns a write only password schema, as
+// rest-layer stores a hash of the password and never returns it.
+func generateSchemaFromFieldPassword(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.Password)
+	ret := &openapi3.Schema{
+		Type:      "string",
+		Format:    "password",
+		WriteOnly: true,
+		MinLength: uint64(v.MinLen),
+	}
+	if v.MaxLen > 0 {
+		ret.MaxLength = openapi3.Uint64Ptr(uint64(v.MaxLen))
+	}
+
+	return ret
+}
@@ -5,18 +5,58 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/jinzhu/inflection"
 	"github.com/rs/rest-layer/resource"
+	"github.com/rs/rest-layer/schema"
 	"strings"
 )
 
-func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *resource.Resource) {
+func (g *generator) addResource(prevRscList []*resource.Resource, rsc *resource.Resource) {
+	doc := g.doc
+	tag := g.addTag(prevRscList, rsc)
+
 	schemaNamePlural := rsc.Name()
-	schemaNameSingular := inflection.Singular(rsc.Name())
+	schemaNameSingular := g.schemaNames[rsc.Path()]
+	// operationIds derive from the resource name rather than the schema name,
+	// which changes when another resource gets the same singular name.
+	operationNameSingular := inflection.Singular(rsc.Name())
 	schemaIdParameter := schemaNameSingular + "Id"
 
+	def := g.withSubResources(prevRscList, rsc, g.filterSchema(rsc))
+	if len(prevRscList) > 0 {
+		def = withParentField(def, rsc.ParentField())
+	}
+	rscSchema := g.generateSchema(def)
+	if id, ok := def.Fields["id"]; ok && rscSchema.Properties["id"].Value != nil {
+		describeID(id, rscSchema.Properties["id"].Value)
+	}
+	requestExample := exampleFromSchema(def, true)
+	g.applyHiddenFields(def, rscSchema, requestExample)
+	g.resolveConnections(rsc, def, rscSchema)
+	if len(prevRscList) > 0 {
+		parentIdParameter := g.schemaNames[prevRscList[len(prevRscList)-1].Path()] + "Id"
+		describeParentField(rscSchema, rsc.ParentField(), parentIdParameter)
+	}
+	g.applyAnnotations(rsc.Path(), rscSchema)
+	g.runSchemaHooks(rsc, def, rscSchema)
 	doc.Components.Schemas[schemaNameSingular] = &openapi3.SchemaRef{
-		Value: generateSchema(rsc.Schema()),
+		Value: rscSchema,
+	}
+
+	documentedAll := true
+	for fieldName := range rsc.Schema().Fields {
+		if _, found := rscSchema.Properties[fieldName]; !found {
+			documentedAll = false
+		}
+	}
+	doc.Components.Schemas[schemaNameSingular+"Input"] = &openapi3.SchemaRef{
+		Value: generateInputSchema(def, rscSchema, requestExample, documentedAll),
 	}
 
+	doc.Components.Schemas[schemaNameSingular+"Patch"] = &openapi3.SchemaRef{
+		Value: generateJSONPatchSchema(def),
+	}
+
+	fieldsParameter := g.addFieldsParameter(rsc, def, schemaNameSingular)
+
 	doc.Components.Parameters[schemaIdParameter] = &openapi3.ParameterRef{
 		Value: &openapi3.Parameter{
 			Name:        schemaIdParameter,
@@ -29,13 +69,13 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 		},
 	}
 
-	var path string
+	path := g.pathPrefix
 	var operationSufix string
 	var params []*openapi3.ParameterRef
 	for _, prevRsc := range prevRscList {
 		prevSchemaNamePlural := prevRsc.Name()
 		prevSchemaNameSingular := inflection.Singular(prevRsc.Name())
-		prevSchemaIdParameter := prevSchemaNameSingular + "Id"
+		prevSchemaIdParameter := g.schemaNames[prevRsc.Path()] + "Id"
 
 		path = path + fmt.Sprintf("/%s/{%s}", prevSchemaNamePlural, prevSchemaIdParameter)
 		operationSufix = operationSufix + fmt.Sprintf("On%s", strings.Title(prevSchemaNameSingular))
@@ -49,28 +89,20 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 
 	path = path + fmt.Sprintf("/%s", schemaNamePlural)
 
-	if rsc.Conf().IsModeAllowed(resource.List) {
+	var aliasPaths []string
+	if g.isModeAllowed(rsc, resource.List) {
 		op := &openapi3.Operation{
-			OperationID: "List" + strings.Title(schemaNamePlural) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.List, "List"+strings.Title(schemaNamePlural)+operationSufix),
 			Parameters: append(
-				[]*openapi3.ParameterRef{
-					{Ref: "#/components/parameters/filter"},
-					{Ref: "#/components/parameters/fields"},
-					{Ref: "#/components/parameters/limit"},
-					{Ref: "#/components/parameters/page"},
-					{Ref: "#/components/parameters/skip"},
-					{Ref: "#/components/parameters/total"},
-				},
+				listParameters(doc, rsc, schemaNameSingular, fieldsParameter),
 				params...
 			),
 			Responses: map[string]*openapi3.ResponseRef{
 				"200": &openapi3.ResponseRef{
 					Value: &openapi3.Response{
 						Description: fmt.Sprintf("List of %s", schemaNamePlural),
-						Headers: map[string]*openapi3.HeaderRef{
-							"Date":    {Ref: "#/components/headers/Date"}, // TODO: Verify
-							"X-Total": {Ref: "#/components/headers/X-Total"},
-						},
+						Headers:     listHeaders(rsc),
 						Content: map[string]*openapi3.MediaType{
 							"application/json": &openapi3.MediaType{
 								Schema: &openapi3.SchemaRef{
@@ -90,12 +122,33 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "GET", op)
+		g.addOperation(rsc, path, "GET", resource.List, op)
+
+		for _, alias := range rsc.GetAliases() {
+			if !g.includeAlias(rsc, alias) {
+				continue
+			}
+			query, _ := rsc.GetAlias(alias)
+			aliasPath := path + fmt.Sprintf("/%s", alias)
+			aliasOp := &openapi3.Operation{
+				Tags:        []string{tag},
+				OperationID: g.aliasOperationID(rsc, alias, "List"+strings.Title(schemaNamePlural)+strings.Title(alias)+operationSufix),
+				Description: fmt.Sprintf("Alias of the %s list with `%s`.", schemaNamePlural, query.Encode()),
+				Parameters: append(
+					listParameters(doc, rsc, schemaNameSingular, fieldsParameter),
+					params...
+				),
+				Responses: op.Responses,
+			}
+			g.addOperation(rsc, aliasPath, "GET", resource.List, aliasOp)
+			aliasPaths = append(aliasPaths, aliasPath)
+		}
 	}
 
-	if rsc.Conf().IsModeAllowed(resource.Create) {
+	if g.isModeAllowed(rsc, resource.Create) {
 		op := &openapi3.Operation{
-			OperationID: "Create" + strings.Title(schemaNameSingular) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.Create, "Create"+strings.Title(operationNameSingular)+operationSufix),
 			Parameters: append(
 				[]*openapi3.ParameterRef{},
 				params...
@@ -105,7 +158,7 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 					Content: map[string]*openapi3.MediaType{
 						"application/json": &openapi3.MediaType{
 							Schema: &openapi3.SchemaRef{
-								Ref: fmt.Sprintf("#/components/schemas/%s", schemaNameSingular),
+								Ref: fmt.Sprintf("#/components/schemas/%sInput", schemaNameSingular),
 							},
 						},
 					},
@@ -116,8 +169,9 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 					Value: &openapi3.Response{
 						Description: fmt.Sprintf("Create %s", schemaNameSingular),
 						Headers: map[string]*openapi3.HeaderRef{
-							"Etag":          {Ref: "#/components/headers/Etag"},
-							"Last-Modified": {Ref: "#/components/headers/Last-Modified"},
+							"Content-Location": {Ref: "#/components/headers/Content-Location"},
+							"Etag":             {Ref: "#/components/headers/Etag"},
+							"Last-Modified":    {Ref: "#/components/headers/Last-Modified"},
 						},
 						Content: map[string]*openapi3.MediaType{
 							"application/json": &openapi3.MediaType{
@@ -136,12 +190,13 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "POST", op)
+		g.addOperation(rsc, path, "POST", resource.Create, op)
 	}
 
-	if rsc.Conf().IsModeAllowed(resource.Clear) {
+	if g.isModeAllowed(rsc, resource.Clear) {
 		op := &openapi3.Operation{
-			OperationID: "Clear" + strings.Title(rsc.Name()) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.Clear, "Clear"+strings.Title(rsc.Name())+operationSufix),
 			Parameters: append(
 				[]*openapi3.ParameterRef{
 					{Ref: "#/components/parameters/filter"},
@@ -163,17 +218,19 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "DELETE", op)
+		g.addOperation(rsc, path, "DELETE", resource.Clear, op)
 	}
 
+	collectionPath := path
 	path = path + fmt.Sprintf("/{%s}", schemaIdParameter)
 
-	if rsc.Conf().IsModeAllowed(resource.Read) {
+	if g.isModeAllowed(rsc, resource.Read) {
 		op := &openapi3.Operation{
-			OperationID: "Read" + strings.Title(schemaNameSingular) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.Read, "Read"+strings.Title(operationNameSingular)+operationSufix),
 			Parameters: append(
 				[]*openapi3.ParameterRef{
-					{Ref: "#/components/parameters/fields"},
+					{Ref: fmt.Sprintf("#/components/parameters/%s", fieldsParameter)},
 					{Ref: fmt.Sprintf("#/components/parameters/%s", schemaIdParameter)},
 				},
 				params...
@@ -205,12 +262,13 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "GET", op)
+		g.addOperation(rsc, path, "GET", resource.Read, op)
 	}
 
-	if rsc.Conf().IsModeAllowed(resource.Replace) {
+	if g.isModeAllowed(rsc, resource.Replace) {
 		op := &openapi3.Operation{
-			OperationID: "Replace" + strings.Title(schemaNameSingular) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.Replace, "Replace"+strings.Title(operationNameSingular)+operationSufix),
 			Parameters: append(
 				[]*openapi3.ParameterRef{
 					{Ref: fmt.Sprintf("#/components/parameters/%s", schemaIdParameter)},
@@ -222,7 +280,7 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 					Content: map[string]*openapi3.MediaType{
 						"application/json": &openapi3.MediaType{
 							Schema: &openapi3.SchemaRef{
-								Ref: fmt.Sprintf("#/components/schemas/%s", schemaNameSingular),
+								Ref: fmt.Sprintf("#/components/schemas/%sInput", schemaNameSingular),
 							},
 						},
 					},
@@ -258,12 +316,13 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "PUT", op)
+		g.addOperation(rsc, path, "PUT", resource.Replace, op)
 	}
 
-	if rsc.Conf().IsModeAllowed(resource.Update) {
+	if g.isModeAllowed(rsc, resource.Update) {
 		op := &openapi3.Operation{
-			OperationID: "Update" + strings.Title(schemaNameSingular) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.Update, "Update"+strings.Title(operationNameSingular)+operationSufix),
 			Parameters: append(
 				[]*openapi3.ParameterRef{
 					{Ref: fmt.Sprintf("#/components/parameters/%s", schemaIdParameter)},
@@ -275,7 +334,12 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 					Content: map[string]*openapi3.MediaType{
 						"application/json": &openapi3.MediaType{
 							Schema: &openapi3.SchemaRef{
-								Ref: fmt.Sprintf("#/components/schemas/%s", schemaNameSingular),
+								Ref: fmt.Sprintf("#/components/schemas/%sInput", schemaNameSingular),
+							},
+						},
+						"application/json-patch+json": &openapi3.MediaType{
+							Schema: &openapi3.SchemaRef{
+								Ref: fmt.Sprintf("#/components/schemas/%sPatch", schemaNameSingular),
 							},
 						},
 					},
@@ -311,12 +375,13 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "PATCH", op)
+		g.addOperation(rsc, path, "PATCH", resource.Update, op)
 	}
 
-	if rsc.Conf().IsModeAllowed(resource.Delete) {
+	if g.isModeAllowed(rsc, resource.Delete) {
 		op := &openapi3.Operation{
-			OperationID: "Delete" + strings.Title(schemaNameSingular) + operationSufix,
+			Tags:        []string{tag},
+			OperationID: g.operationID(rsc, resource.Delete, "Delete"+strings.Title(operationNameSingular)+operationSufix),
 			Parameters: append(
 				[]*openapi3.ParameterRef{
 					{Ref: fmt.Sprintf("#/components/parameters/%s", schemaIdParameter)},
@@ -337,10 +402,122 @@ func addResource(doc *openapi3.Swagger, prevRscList []*resource.Resource, rsc *r
 				},
 			},
 		}
-		doc.AddOperation(path, "DELETE", op)
+		g.addOperation(rsc, path, "DELETE", resource.Delete, op)
 	}
 
+	g.runPathHooks(rsc, append([]string{collectionPath, path}, aliasPaths...)...)
+
 	for _, subRsc := range rsc.GetResources() {
-		addResource(doc, append(prevRscList, rsc), subRsc)
+		if !g.includeResource(append(prevRscList, rsc), subRsc) {
+			continue
+		}
+		g.addResource(append(prevRscList, rsc), subRsc)
+	}
+}
+
+// listParameters returns the query parameters of the List operation of rsc,
+// adjusted to its pagination and total settings. When the resource has a
+// default page size, a resource specific limit parameter is registered.
+func listParameters(doc *openapi3.Swagger, rsc *resource.Resource, schemaNameSingular, fieldsParameter string) []*openapi3.ParameterRef {
+	conf := rsc.Conf()
+
+	params := []*openapi3.ParameterRef{
+		{Ref: "#/components/parameters/filter"},
+		{Ref: fmt.Sprintf("#/components/parameters/%s", fieldsParameter)},
+	}
+
+	if conf.PaginationDefaultLimit > 0 {
+		limitParameter := schemaNameSingular + "Limit"
+		doc.Components.Parameters[limitParameter] = &openapi3.ParameterRef{
+			Value: &openapi3.Parameter{
+				Description: fmt.Sprintf("Limit maximum entries per [page](http://rest-layer.io/#pagination), %d by default.", conf.PaginationDefaultLimit),
+				Name:        "limit",
+				In:          "query",
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type:    "integer",
+						Default: openapi3.Float64Ptr(float64(conf.PaginationDefaultLimit)),
+						Min:     openapi3.Float64Ptr(0),
+					},
+				},
+			},
+		}
+		params = append(params,
+			&openapi3.ParameterRef{Ref: fmt.Sprintf("#/components/parameters/%s", limitParameter)},
+			&openapi3.ParameterRef{Ref: "#/components/parameters/page"},
+		)
+	} else {
+		// Without a default page size, rest-layer rejects the page parameter
+		// unless a limit is given, so pagination is not advertised.
+		params = append(params, &openapi3.ParameterRef{Ref: "#/components/parameters/limit"})
+	}
+
+	params = append(params, &openapi3.ParameterRef{Ref: "#/components/parameters/skip"})
+
+	if conf.ForceTotal == resource.TotalOptIn {
+		params = append(params, &openapi3.ParameterRef{Ref: "#/components/parameters/total"})
+	}
+
+	return params
+}
+
+// listHeaders returns the headers of the List operation response of rsc,
+// according to its ForceTotal setting.
+func listHeaders(rsc *resource.Resource) map[string]*openapi3.HeaderRef {
+	headers := map[string]*openapi3.HeaderRef{
+		"Date": {Ref: "#/components/headers/Date"}, // TODO: Verify
+	}
+
+	switch rsc.Conf().ForceTotal {
+	case resource.TotalOptIn:
+		headers["X-Total"] = &openapi3.HeaderRef{Ref: "#/components/headers/X-Total"}
+	case resource.TotalAlways:
+		headers["X-Total"] = &openapi3.HeaderRef{
+			Value: &openapi3.Header{
+				Description: "Total number of entries matching the supplied filter, always computed for this resource.",
+				Required:    true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: "integer",
+					},
+				},
+			},
+		}
+	}
+
+	return headers
+}
+
+// withParentField returns a copy of s where the field referencing the parent
+// of a sub-resource is read only, as rest-layer sets it from the URL path.
+func withParentField(s schema.Schema, parentField string) schema.Schema {
+	field, found := s.Fields[parentField]
+	if !found {
+		return s
+	}
+
+	fields := schema.Fields{}
+	for fieldName, f := range s.Fields {
+		fields[fieldName] = f
+	}
+	field.ReadOnly = true
+	fields[parentField] = field
+	s.Fields = fields
+
+	return s
+}
+
+// describeParentField documents the field referencing the parent of a
+// sub-resource as derived from the parentIdParameter path parameter.
+func describeParentField(s *openapi3.Schema, parentField, parentIdParameter string) {
+	prop, found := s.Properties[parentField]
+	if !found || prop.Value == nil {
+		return
+	}
+	prop.Value.ReadOnly = true
+	description := fmt.Sprintf("Derived from the {%s} path parameter.", parentIdParameter)
+	if prop.Value.Description != "" {
+		description = prop.Value.Description + " " + description
 	}
+	prop.Value.Description = description
 }
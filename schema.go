@@ -3,12 +3,16 @@ package openapi
 import (
 	"os"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"github.com/rs/rest-layer/schema"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-func generateSchema(s schema.Schema) *openapi3.Schema {
+func (g *generator) generateSchema(s schema.Schema) *openapi3.Schema {
 	ret := &openapi3.Schema{
 		Type:        "object",
 		Description: s.Description,
@@ -17,20 +21,173 @@ func generateSchema(s schema.Schema) *openapi3.Schema {
 
 	for fieldName, field := range s.Fields {
 		ret.Properties[fieldName] = &openapi3.SchemaRef{}
-		ret.Properties[fieldName].Value = generateSchemaFromField(field)
+		ret.Properties[fieldName].Value = g.generateSchemaFromField(field)
+		if prop := ret.Properties[fieldName].Value; prop != nil {
+			if prop.Example == nil {
+				prop.Example = exampleFromField(field)
+			}
+			describeComputed(field, prop)
+			describeDependency(field, prop)
+		}
 	}
+	ret.Example = exampleFromSchema(s, false)
 
 	return ret
 }
 
-func generateSchemaFromField(field schema.Field) *openapi3.Schema {
+// generateInputSchema returns the schema of the request bodies of a resource,
+// a copy of ret, the schema generated from its documented schema s, with the
+// request example. rest-layer rejects unknown fields, but responses also carry
+// _etag, aliased and connection fields, hence a separate schema. When
+// documentedAll is false, some fields were left out of the document, so
+// unknown fields are not forbidden.
+//
+// The number of fields can't be expressed as minProperties and maxProperties:
+// rest-layer checks it on the item once default, computed and stored values
+// are applied, not on the request body. It is described instead.
+func generateInputSchema(s schema.Schema, ret *openapi3.Schema, requestExample map[string]interface{}, documentedAll bool) *openapi3.Schema {
+	input := *ret
+	input.Example = requestExample
+	if documentedAll {
+		additionalProperties := false
+		input.AdditionalPropertiesAllowed = &additionalProperties
+	}
+
+	var limits []string
+	input.Extensions = map[string]interface{}{}
+	for k, v := range ret.Extensions {
+		input.Extensions[k] = v
+	}
+	if s.MinLen > 0 {
+		limits = append(limits, fmt.Sprintf("at least %d", s.MinLen))
+		input.Extensions["x-rest-layer-min-fields"] = s.MinLen
+	}
+	if s.MaxLen > 0 {
+		limits = append(limits, fmt.Sprintf("at most %d", s.MaxLen))
+		input.Extensions["x-rest-layer-max-fields"] = s.MaxLen
+	}
+	if len(limits) > 0 {
+		desc := fmt.Sprintf("Once default, computed and stored values are applied, the item must have %s fields.", strings.Join(limits, " and "))
+		if input.Description != "" {
+			desc = strings.TrimSuffix(input.Description, ".") + ". " + desc
+		}
+		input.Description = desc
+	}
+
+	return &input
+}
+
+// describeID documents whether the id of an item is supplied by the client or
+// generated by the server (i.e. schema.IDField with OnInit set to schema.NewID).
+func describeID(f schema.Field, s *openapi3.Schema) {
+	switch {
+	case f.OnInit != nil && f.ReadOnly:
+		s.ReadOnly = true
+		s.Description = "Generated by the server on creation, must not be provided."
+	case f.OnInit != nil:
+		s.Description = "Generated by the server on creation if not provided."
+	default:
+		s.Description = "Must be provided by the client on creation."
+	}
+	if f.Description != "" {
+		s.Description = strings.TrimSuffix(f.Description, ".") + ". " + s.Description
+	}
+}
+
+// generateJSONPatchSchema returns the schema of a RFC 6902 JSON Patch document
+// whose paths are restricted to the writable fields of s.
+func generateJSONPatchSchema(s schema.Schema) *openapi3.Schema {
+	writable := []string{}
+	for fieldName, field := range s.Fields {
+		if _, ok := field.Validator.(*schema.Connection); ok {
+			continue
+		}
+		if !field.ReadOnly {
+			writable = append(writable, regexp.QuoteMeta(fieldName))
+		}
+	}
+	sort.Strings(writable)
+
+	pathPattern := fmt.Sprintf("^/(%s)(/.*)?$", strings.Join(writable, "|"))
+
+	return &openapi3.Schema{
+		Type: "array",
+		Items: &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Type:     "object",
+				Required: []string{"op", "path"},
+				Properties: map[string]*openapi3.SchemaRef{
+					"op": &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type: "string",
+							Enum: []interface{}{"add", "remove", "replace", "move", "copy", "test"},
+						},
+					},
+					"path": &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type:    "string",
+							Pattern: pathPattern,
+						},
+					},
+					"from": &openapi3.SchemaRef{
+						Value: &openapi3.Schema{
+							Type:    "string",
+							Pattern: pathPattern,
+						},
+					},
+					"value": &openapi3.SchemaRef{
+						Value: &openapi3.Schema{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (g *generator) generateSchemaFromField(field schema.Field) *openapi3.Schema {
+	if ret := g.generateSchemaFromCustomValidator(field); ret != nil {
+		return ret
+	}
+
 	switch t := field.Validator.(type) {
 	case *schema.String:
 		return generateSchemaFromFieldString(field)
+	case *schema.Integer:
+		return generateSchemaFromFieldInteger(field)
+	case *schema.Float:
+		return generateSchemaFromFieldFloat(field)
+	case *schema.Bool:
+		return generateSchemaFromFieldBool(field)
 	case *schema.Array:
-		return generateSchemaFromFieldArray(field)
+		return g.generateSchemaFromFieldArray(field)
+	case *schema.Object:
+		return g.generateSchemaFromFieldObject(field)
+	case *schema.Time:
+		return generateSchemaFromFieldTime(field)
+	case *schema.Password:
+		return generateSchemaFromFieldPassword(field)
 	case *schema.Reference:
 		return generateSchemaFromFieldReference(field)
+	case *schema.Connection:
+		return generateSchemaFromFieldConnection(field)
+	case *schema.Null:
+		return generateSchemaFromFieldNull(field)
+	case *schema.AnyOf:
+		return g.generateSchemaFromFieldAnyOf(field)
+	// rest-layer uses validators by value for the parameters of the
+	// connections to sub-resources, and commonly for AnyOf and Null.
+	case schema.String:
+		field.Validator = &t
+		return g.generateSchemaFromField(field)
+	case schema.Integer:
+		field.Validator = &t
+		return g.generateSchemaFromField(field)
+	case schema.Null:
+		field.Validator = &t
+		return g.generateSchemaFromField(field)
+	case schema.AnyOf:
+		field.Validator = &t
+		return g.generateSchemaFromField(field)
 	default:
 		fmt.Fprintln(os.Stderr, "Unsupported Type:", reflect.TypeOf(t))
 		return nil
@@ -53,13 +210,58 @@ func generateSchemaFromFieldString(f schema.Field) *openapi3.Schema {
 	return ret
 }
 
-func generateSchemaFromFieldArray(f schema.Field) *openapi3.Schema {
+func generateSchemaFromFieldInteger(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.Integer)
+	ret := &openapi3.Schema{
+		Type: "integer",
+	}
+	for _, allowed := range v.Allowed {
+		ret.Enum = append(ret.Enum, allowed)
+	}
+	applyBoundaries(ret, v.Boundaries)
+
+	return ret
+}
+
+func generateSchemaFromFieldFloat(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.Float)
+	ret := &openapi3.Schema{
+		Type: "number",
+	}
+	for _, allowed := range v.Allowed {
+		ret.Enum = append(ret.Enum, allowed)
+	}
+	applyBoundaries(ret, v.Boundaries)
+
+	return ret
+}
+
+// applyBoundaries sets the finite bounds of b as minimum and maximum of s.
+func applyBoundaries(s *openapi3.Schema, b *schema.Boundaries) {
+	if b == nil {
+		return
+	}
+	if !math.IsInf(b.Min, 0) {
+		s.Min = openapi3.Float64Ptr(b.Min)
+	}
+	if !math.IsInf(b.Max, 0) {
+		s.Max = openapi3.Float64Ptr(b.Max)
+	}
+}
+
+func generateSchemaFromFieldBool(f schema.Field) *openapi3.Schema {
+	return &openapi3.Schema{
+		Type: "boolean",
+	}
+}
+
+func (g *generator) generateSchemaFromFieldArray(f schema.Field) *openapi3.Schema {
 	v := f.Validator.(*schema.Array)
 	ret := &openapi3.Schema{
 		Type:     "array",
 		MinItems: uint64(v.MinLen),
 		Items: &openapi3.SchemaRef{
-			Value: generateSchemaFromField(v.Values),
+			Value: g.generateSchemaFromField(v.Values),
 		},
 	}
 	if v.MaxLen > 0 {
@@ -69,6 +271,27 @@ func generateSchemaFromFieldArray(f schema.Field) *openapi3.Schema {
 	return ret
 }
 
+func (g *generator) generateSchemaFromFieldObject(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.Object)
+	if v.Schema == nil {
+		return &openapi3.Schema{
+			Type: "object",
+		}
+	}
+
+	// Unlike items, objects are validated as given, so unknown fields and
+	// the number of fields can be checked by the schema.
+	ret := g.generateSchema(*v.Schema)
+	additionalProperties := false
+	ret.AdditionalPropertiesAllowed = &additionalProperties
+	ret.MinProps = uint64(v.Schema.MinLen)
+	if v.Schema.MaxLen > 0 {
+		ret.MaxProps = openapi3.Uint64Ptr(uint64(v.Schema.MaxLen))
+	}
+
+	return ret
+}
+
 func generateSchemaFromFieldReference(f schema.Field) *openapi3.Schema {
 	ret := &openapi3.Schema{
 		Type: "string",
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+)
+
+// WithSecurityScheme declares a security scheme in the document components.
+func WithSecurityScheme(name string, scheme *openapi3.SecurityScheme) Option {
+	return func(g *generator) {
+		if g.doc.Components.SecuritySchemes == nil {
+			g.doc.Components.SecuritySchemes = map[string]*openapi3.SecuritySchemeRef{}
+		}
+		g.doc.Components.SecuritySchemes[name] = &openapi3.SecuritySchemeRef{
+			Value: scheme,
+		}
+	}
+}
+
+// WithSecurity sets the security requirements applied to every operation.
+func WithSecurity(reqs ...openapi3.SecurityRequirement) Option {
+	return func(g *generator) {
+		g.doc.Security = openapi3.SecurityRequirements(reqs)
+	}
+}
+
+// WithResourceSecurity sets the security requirements of every operation of
+// the resource at path (i.e. "users.posts"), overriding the global ones. No
+// requirement makes the resource public.
+func WithResourceSecurity(path string, reqs ...openapi3.SecurityRequirement) Option {
+	return func(g *generator) {
+		if g.resourceSecurity == nil {
+			g.resourceSecurity = map[string]openapi3.SecurityRequirements{}
+		}
+		g.resourceSecurity[path] = openapi3.SecurityRequirements(reqs)
+	}
+}
+
+// WithModeSecurity sets the security requirements of the operation handling
+// mode on the resource at path, overriding the resource and global ones. No
+// requirement makes the operation public.
+func WithModeSecurity(path string, mode resource.Mode, reqs ...openapi3.SecurityRequirement) Option {
+	return func(g *generator) {
+		if g.modeSecurity == nil {
+			g.modeSecurity = map[string]map[resource.Mode]openapi3.SecurityRequirements{}
+		}
+		if g.modeSecurity[path] == nil {
+			g.modeSecurity[path] = map[resource.Mode]openapi3.SecurityRequirements{}
+		}
+		g.modeSecurity[path][mode] = openapi3.SecurityRequirements(reqs)
+	}
+}
+
+// operationSecurity returns the security requirements of the operation
+// handling mode on rsc, or nil when the global ones apply.
+func (g *generator) operationSecurity(rsc *resource.Resource, mode resource.Mode) *openapi3.SecurityRequirements {
+	if reqs, found := g.modeSecurity[rsc.Path()][mode]; found {
+		return nonNilSecurity(reqs)
+	}
+	if reqs, found := g.resourceSecurity[rsc.Path()]; found {
+		return nonNilSecurity(reqs)
+	}
+	return nil
+}
+
+// nonNilSecurity makes sure an empty list of requirements is rendered as
+// "security: []" so that the operation is public.
+func nonNilSecurity(reqs openapi3.SecurityRequirements) *openapi3.SecurityRequirements {
+	if reqs == nil {
+		reqs = openapi3.SecurityRequirements{}
+	}
+	return &reqs
+}
@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// WithServers adds servers, possibly using URL template variables, to the
+// document.
+func WithServers(servers ...*openapi3.Server) Option {
+	return func(g *generator) {
+		g.doc.Servers = append(g.doc.Servers, servers...)
+	}
+}
+
+// WithEnvironmentServer adds a server entry serving the API of the given
+// environment (i.e. "production", "staging") at url.
+func WithEnvironmentServer(environment, url string) Option {
+	return func(g *generator) {
+		g.doc.Servers = append(g.doc.Servers, &openapi3.Server{
+			URL:         url,
+			Description: environment,
+		})
+	}
+}
+
+// WithPathPrefix sets the prefix the rest-layer handler is mounted under
+// (i.e. "/api/v1"), prepended to every generated path.
+func WithPathPrefix(prefix string) Option {
+	return func(g *generator) {
+		prefix = strings.Trim(prefix, "/")
+		if prefix != "" {
+			prefix = "/" + prefix
+		}
+		g.pathPrefix = prefix
+	}
+}
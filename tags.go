@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/resource"
+)
+
+// tagGroup is an entry of the x-tagGroups extension, grouping the tag of a
+// top level resource with the tags of all its sub-resources.
+type tagGroup struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// WithTagNamer sets the function used to name the tag of each resource.
+// Defaults to the resource path (i.e. "users.posts").
+func WithTagNamer(namer func(rsc *resource.Resource) string) Option {
+	return func(g *generator) {
+		g.tagNamer = namer
+	}
+}
+
+func defaultTagNamer(rsc *resource.Resource) string {
+	return rsc.Path()
+}
+
+// addTag registers the tag of rsc in the document and in the tag group of its
+// top level resource, and returns its name.
+func (g *generator) addTag(prevRscList []*resource.Resource, rsc *resource.Resource) string {
+	name := g.tagNamer(rsc)
+
+	g.doc.Tags = append(g.doc.Tags, &openapi3.Tag{
+		Name:        name,
+		Description: rsc.Schema().Description,
+	})
+
+	if len(prevRscList) == 0 {
+		g.tagGroups = append(g.tagGroups, &tagGroup{Name: name})
+	}
+	group := g.tagGroups[len(g.tagGroups)-1]
+	group.Tags = append(group.Tags, name)
+
+	return name
+}
+
+func (g *generator) addTagGroups() {
+	if len(g.tagGroups) == 0 {
+		return
+	}
+	if g.doc.Extensions == nil {
+		g.doc.Extensions = map[string]interface{}{}
+	}
+	g.doc.Extensions["x-tagGroups"] = g.tagGroups
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+// generateSchemaFromFieldTime returns a date-time string schema, as rest-layer
+// always returns times in RFC 3339. Layouts accepted in requests are
+// documented in the description, and listed in the x-rest-layer-time-layouts
+// extension, when they differ from the default RFC 3339.
+func generateSchemaFromFieldTime(f schema.Field) *openapi3.Schema {
+	v := f.Validator.(*schema.Time)
+	ret := &openapi3.Schema{
+		Type:   "string",
+		Format: "date-time",
+	}
+
+	if !acceptsRFC3339(v) {
+		ret.Description = "Returned in RFC 3339, but only accepts " + describeLayouts(v.TimeLayouts) + " in requests."
+	} else {
+		var layouts []string
+		for _, layout := range v.TimeLayouts {
+			if layout != time.RFC3339 && layout != time.RFC3339Nano {
+				layouts = append(layouts, layout)
+			}
+		}
+		if len(layouts) == 0 {
+			return ret
+		}
+		ret.Description = "Also accepts " + describeLayouts(layouts) + " in requests."
+	}
+	ret.Extensions = map[string]interface{}{
+		"x-rest-layer-time-layouts": v.TimeLayouts,
+	}
+
+	return ret
+}
+
+// acceptsRFC3339 tells if v accepts RFC 3339 times, which rest-layer does when
+// no layout is set.
+func acceptsRFC3339(v *schema.Time) bool {
+	if len(v.TimeLayouts) == 0 {
+		return true
+	}
+	for _, layout := range v.TimeLayouts {
+		if layout == time.RFC3339 || layout == time.RFC3339Nano {
+			return true
+		}
+	}
+	return false
+}
+
+func describeLayouts(layouts []string) string {
+	desc := "the `" + strings.Join(layouts, "`, `") + "` Go time layout"
+	if len(layouts) > 1 {
+		desc += "s"
+	}
+	return desc
+}
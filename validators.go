@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/rest-layer/schema"
+)
+
+// OpenAPISchemer is implemented by custom schema.FieldValidator types able to
+// describe their own OpenAPI schema.
+type OpenAPISchemer interface {
+	OpenAPISchema() *openapi3.Schema
+}
+
+// SchemaFunc generates the OpenAPI schema of a field.
+type SchemaFunc func(f schema.Field) *openapi3.Schema
+
+// WithValidatorSchema registers fn to generate the schema of fields whose
+// validator has the same type as v. It is meant for third-party validators
+// that can't implement OpenAPISchemer.
+func WithValidatorSchema(v schema.FieldValidator, fn SchemaFunc) Option {
+	return func(g *generator) {
+		if g.validatorSchemas == nil {
+			g.validatorSchemas = map[reflect.Type]SchemaFunc{}
+		}
+		g.validatorSchemas[reflect.TypeOf(v)] = fn
+	}
+}
+
+// generateSchemaFromCustomValidator returns the schema of a field validated by
+// an OpenAPISchemer or a registered validator, or nil otherwise. The schema is
+// copied, as it is completed for the field afterwards.
+func (g *generator) generateSchemaFromCustomValidator(f schema.Field) *openapi3.Schema {
+	if s, ok := f.Validator.(OpenAPISchemer); ok {
+		return copySchema(s.OpenAPISchema())
+	}
+	if fn, found := g.validatorSchemas[reflect.TypeOf(f.Validator)]; found {
+		return copySchema(fn(f))
+	}
+	return nil
+}
+
+// copySchema returns a shallow copy of s with its own extensions.
+func copySchema(s *openapi3.Schema) *openapi3.Schema {
+	if s == nil {
+		return nil
+	}
+	ret := *s
+	if s.Extensions != nil {
+		ret.Extensions = make(map[string]interface{}, len(s.Extensions))
+		for name, value := range s.Extensions {
+			ret.Extensions[name] = value
+		}
+	}
+	return &ret
+}